@@ -0,0 +1,123 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package appconfig defines the agent's on-disk configuration schema,
+// SsmagentConfig, along with the defaults used when a stanza is omitted.
+package appconfig
+
+const (
+	// DefaultDataStorePath is the root directory the agent persists
+	// command/document state under.
+	DefaultDataStorePath = "/var/lib/amazon/ssm"
+
+	// DefaultCommandRootDirName is the subdirectory, under an instance's
+	// data store path, that orchestration state is written to.
+	DefaultCommandRootDirName = "document"
+)
+
+// Mds defaults, used when the config file omits the corresponding field.
+const (
+	// DefaultCommandWorkersLimit bounds how many sendCommand plugins run concurrently.
+	DefaultCommandWorkersLimit = 5
+
+	// DefaultMdsStopTimeoutMillis is the default time SendReply waits for the MDS call to finish.
+	DefaultMdsStopTimeoutMillis = 20000
+
+	// DefaultMdsCredentialsCacheExpiryWindowMinutes is how early, relative to
+	// actual expiry, cached MDS credentials are refreshed.
+	DefaultMdsCredentialsCacheExpiryWindowMinutes = 5
+
+	// DefaultMdsCredentialsCacheExpiryWindowJitterFrac randomizes the expiry
+	// window by up to this fraction so a fleet's agents don't refresh IMDS
+	// credentials in lockstep.
+	DefaultMdsCredentialsCacheExpiryWindowJitterFrac = 0.2
+)
+
+// AgentInfo is the `Agent` stanza of SsmagentConfig.
+type AgentInfo struct {
+	// Name is the agent's reported name, e.g. amazon-ssm-agent.
+	Name string
+	// Version is the agent's reported version string.
+	Version string
+	// Region is the AWS region the agent operates in.
+	Region string
+	// OrchestrationRootDir is appended under DefaultDataStorePath/instanceID
+	// to build the directory plugin orchestration logs are written to.
+	OrchestrationRootDir string
+}
+
+// OsInfo is the `Os` stanza of SsmagentConfig, reporting the host platform.
+type OsInfo struct {
+	// Lang is the host's configured locale.
+	Lang string
+	// Name is the host OS name, e.g. Windows Server 2019.
+	Name string
+	// Version is the host OS version.
+	Version string
+}
+
+// MdsCfg is the `Mds` stanza of SsmagentConfig.
+type MdsCfg struct {
+	// Endpoint overrides the MDS service endpoint; empty uses the SDK default for Region.
+	Endpoint string
+
+	// CommandWorkersLimit bounds how many sendCommand plugins run concurrently.
+	CommandWorkersLimit int
+
+	// StopTimeoutMillis is the minimum time each SendReply call is given to
+	// finish; DefaultsMode may raise it but never lowers it.
+	StopTimeoutMillis int64
+
+	// DefaultsMode selects the aws-sdk-go-v2 defaults mode used to tune MDS
+	// client timeouts and retries: "standard", "in-region", "cross-region",
+	// "mobile", or "auto". Empty, like "auto" or an unrecognized value, keeps
+	// the "standard" retry count and poll frequency but takes its connect
+	// timeout from StopTimeoutMillis when that is set.
+	DefaultsMode string
+
+	// CredentialsCacheExpiryWindowMinutes is how early, relative to actual
+	// expiry, cached MDS credentials are refreshed.
+	CredentialsCacheExpiryWindowMinutes int
+
+	// CredentialsCacheExpiryWindowJitterFrac randomizes
+	// CredentialsCacheExpiryWindowMinutes by up to this fraction, so a
+	// fleet's agents don't refresh IMDS credentials in lockstep.
+	CredentialsCacheExpiryWindowJitterFrac float64
+}
+
+// SsmagentConfig is the parsed form of the agent's on-disk configuration file.
+type SsmagentConfig struct {
+	Agent  AgentInfo
+	Os     OsInfo
+	Mds    MdsCfg
+	Events EventsCfg
+}
+
+// DefaultConfig returns an SsmagentConfig with the Mds and Events stanzas
+// populated from the package's Default* constants (Agent and Os are left
+// empty), for callers that have no config file to parse (e.g. tests).
+func DefaultConfig() SsmagentConfig {
+	return SsmagentConfig{
+		Mds: MdsCfg{
+			CommandWorkersLimit:                    DefaultCommandWorkersLimit,
+			StopTimeoutMillis:                      DefaultMdsStopTimeoutMillis,
+			DefaultsMode:                           "standard",
+			CredentialsCacheExpiryWindowMinutes:    DefaultMdsCredentialsCacheExpiryWindowMinutes,
+			CredentialsCacheExpiryWindowJitterFrac: DefaultMdsCredentialsCacheExpiryWindowJitterFrac,
+		},
+		Events: EventsCfg{
+			HTTPTimeoutMillis: DefaultEventsHTTPTimeoutMillis,
+			BufferSize:        DefaultEventsBufferSize,
+		},
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package appconfig
+
+// Events defaults, used when the config file omits the corresponding field.
+const (
+	// DefaultEventsHTTPTimeoutMillis bounds how long the HTTP event sink waits for a single POST.
+	DefaultEventsHTTPTimeoutMillis = 5000
+
+	// DefaultEventsBufferSize is the size of the bounded channel events are dispatched through.
+	DefaultEventsBufferSize = 1000
+)
+
+// EventsCfg is the `Events` stanza of SsmagentConfig, configuring where
+// plugin/document CloudEvents are mirrored to.
+type EventsCfg struct {
+	// SinkType selects the EventSink implementation: "http", "file", or
+	// anything else (including empty) for a no-op sink.
+	SinkType string
+
+	// HTTPEndpoint is the URL events are POSTed to when SinkType is "http".
+	HTTPEndpoint string
+
+	// HTTPTimeoutMillis bounds how long a single POST to HTTPEndpoint may take.
+	HTTPTimeoutMillis int
+
+	// FilePath is the JSON-lines file events are appended to when SinkType is "file".
+	FilePath string
+
+	// BufferSize is the capacity of the dispatcher's bounded event channel;
+	// once full, further events are dropped rather than blocking the caller.
+	BufferSize int
+}
@@ -0,0 +1,89 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package eventsink
+
+import (
+	"sync/atomic"
+
+	"github.com/aws/amazon-ssm-agent/agent/log"
+)
+
+// defaultBufferSize bounds the number of in-flight events when the caller
+// does not configure one explicitly.
+const defaultBufferSize = 1000
+
+// Dispatcher delivers events to a Sink from a single goroutine, so delivery
+// for a given documentID is strictly ordered, while Dispatch itself never
+// blocks the caller: a full buffer drops the event and counts it rather than
+// waiting on a slow or unreachable sink.
+type Dispatcher struct {
+	log     log.T
+	sink    Sink
+	events  chan Event
+	dropped uint64
+	done    chan struct{}
+}
+
+// NewDispatcher starts a Dispatcher that delivers events to sink. bufferSize
+// <= 0 falls back to defaultBufferSize.
+func NewDispatcher(log log.T, sink Sink, bufferSize int) *Dispatcher {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	if sink == nil {
+		sink = NopSink{}
+	}
+
+	d := &Dispatcher{
+		log:    log,
+		sink:   sink,
+		events: make(chan Event, bufferSize),
+		done:   make(chan struct{}),
+	}
+	go d.run()
+	return d
+}
+
+func (d *Dispatcher) run() {
+	defer close(d.done)
+	for event := range d.events {
+		if err := d.sink.Send(event); err != nil {
+			d.log.Warnf("event sink failed to deliver event %v: %v", event.ID, err)
+		}
+	}
+}
+
+// Dispatch enqueues event for delivery without blocking. If the buffer is
+// full the event is dropped and DroppedCount is incremented.
+func (d *Dispatcher) Dispatch(event Event) {
+	select {
+	case d.events <- event:
+	default:
+		dropped := atomic.AddUint64(&d.dropped, 1)
+		d.log.Warnf("event sink buffer full, dropped event %v (total dropped: %v)", event.ID, dropped)
+	}
+}
+
+// DroppedCount returns the number of events dropped so far because the
+// buffer was full.
+func (d *Dispatcher) DroppedCount() uint64 {
+	return atomic.LoadUint64(&d.dropped)
+}
+
+// Stop closes the dispatcher and waits for in-flight deliveries to finish.
+// No further events may be dispatched after Stop is called.
+func (d *Dispatcher) Stop() {
+	close(d.events)
+	<-d.done
+}
@@ -0,0 +1,116 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package eventsink
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ssm-agent/agent/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingSink records every event it receives, in the order Send was called.
+type recordingSink struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recordingSink) Send(event Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordingSink) received() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+// unreachableSink always fails, simulating a sink endpoint that cannot be reached.
+type unreachableSink struct{ calls int32 }
+
+func (u *unreachableSink) Send(Event) error {
+	return fmt.Errorf("connection refused")
+}
+
+func TestNewPluginCompletedEventEnvelopeShape(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	event := NewPluginCompletedEvent("msg-1", now, "i-0123456789", "my-document", "aws:runShellScript", []byte(`{"status":"Success"}`))
+
+	assert.Equal(t, SpecVersion, event.SpecVersion)
+	assert.Equal(t, Source, event.Source)
+	assert.Equal(t, PluginCompletedType, event.Type)
+	assert.Equal(t, DataContentType, event.DataContentType)
+	assert.Equal(t, "msg-1/aws:runShellScript", event.ID)
+	assert.Equal(t, "msg-1", event.Extensions["ssmmessageid"])
+	assert.Equal(t, "i-0123456789", event.Extensions["ssminstanceid"])
+	assert.Equal(t, "my-document", event.Extensions["ssmdocumentname"])
+	assert.Equal(t, "aws:runShellScript", event.Extensions["ssmpluginid"])
+}
+
+func TestNewPluginCompletedEventIDIsUniquePerPluginWithinAMessage(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	first := NewPluginCompletedEvent("msg-1", now, "i-1", "my-document", "aws:runShellScript", nil)
+	second := NewPluginCompletedEvent("msg-1", now, "i-1", "my-document", "aws:downloadContent", nil)
+
+	assert.NotEqual(t, first.ID, second.ID, "two plugins replying to the same message must not share an event id")
+}
+
+func TestNewDocumentStatusEventIDIsUniquePerTransitionWithinAMessage(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	first := NewDocumentStatusEvent("msg-1", now, "i-1", "my-document", "InProgress", nil)
+	second := NewDocumentStatusEvent("msg-1", now, "i-1", "my-document", "Success", nil)
+
+	assert.NotEqual(t, first.ID, second.ID, "two status transitions for the same message must not share an event id")
+}
+
+func TestDispatcherPreservesOrderPerDocument(t *testing.T) {
+	sink := &recordingSink{}
+	dispatcher := NewDispatcher(log.NewMockLog(), sink, 10)
+
+	for i := 0; i < 5; i++ {
+		dispatcher.Dispatch(NewPluginCompletedEvent(fmt.Sprintf("msg-%d", i), time.Now(), "i-1", "doc", "plugin", nil))
+	}
+	dispatcher.Stop()
+
+	received := sink.received()
+	if assert.Len(t, received, 5) {
+		for i, event := range received {
+			assert.Equal(t, fmt.Sprintf("msg-%d/plugin", i), event.ID)
+		}
+	}
+}
+
+func TestDispatcherDropsWhenBufferFullWithoutBlocking(t *testing.T) {
+	sink := &unreachableSink{}
+	before := runtime.NumGoroutine()
+	dispatcher := NewDispatcher(log.NewMockLog(), sink, 1)
+
+	for i := 0; i < 50; i++ {
+		dispatcher.Dispatch(NewPluginCompletedEvent(fmt.Sprintf("msg-%d", i), time.Now(), "i-1", "doc", "plugin", nil))
+	}
+	dispatcher.Stop()
+
+	assert.True(t, dispatcher.DroppedCount() > 0, "expected some events to be dropped by a full buffer")
+
+	// give the single delivery goroutine time to exit after Stop.
+	time.Sleep(10 * time.Millisecond)
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before, "dispatcher goroutine should exit after Stop")
+}
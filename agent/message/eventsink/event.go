@@ -0,0 +1,96 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package eventsink mirrors MDS plugin/document replies as CloudEvents 1.0
+// envelopes, dispatched to a pluggable, non-blocking Sink.
+package eventsink
+
+import "time"
+
+const (
+	// SpecVersion is the CloudEvents spec version this package produces.
+	SpecVersion = "1.0"
+
+	// Source identifies this agent as the event source.
+	Source = "aws.ssm.agent"
+
+	// PluginCompletedType is emitted whenever a single plugin's result is sent to MDS.
+	PluginCompletedType = "aws.ssm.agent.plugin.completed"
+
+	// DocumentStatusType is emitted whenever a document-level status update is sent to MDS.
+	DocumentStatusType = "aws.ssm.agent.document.status"
+
+	// DataContentType is the content type of Data for every event this package produces.
+	DataContentType = "application/json"
+)
+
+// Event is a CloudEvents 1.0 envelope carrying an MDS plugin result or
+// document status payload as Data.
+type Event struct {
+	ID              string
+	Source          string
+	SpecVersion     string
+	Type            string
+	Time            time.Time
+	DataContentType string
+	Data            []byte
+
+	// Extensions carries the ssmmessageid, ssminstanceid, ssmdocumentname and
+	// ssmpluginid CloudEvents extension attributes.
+	Extensions map[string]string
+}
+
+// NewPluginCompletedEvent builds the envelope emitted when a single plugin's
+// result is sent to MDS. A single messageID produces one event per plugin,
+// so ID is derived from messageID and pluginID together - per the CloudEvents
+// spec, (id, source) must uniquely identify each distinct event, and the bare
+// messageID would collide across every plugin in the same message.
+func NewPluginCompletedEvent(messageID string, now time.Time, instanceID, documentName, pluginID string, data []byte) Event {
+	return Event{
+		ID:              messageID + "/" + pluginID,
+		Source:          Source,
+		SpecVersion:     SpecVersion,
+		Type:            PluginCompletedType,
+		Time:            now,
+		DataContentType: DataContentType,
+		Data:            data,
+		Extensions: map[string]string{
+			"ssmmessageid":    messageID,
+			"ssminstanceid":   instanceID,
+			"ssmdocumentname": documentName,
+			"ssmpluginid":     pluginID,
+		},
+	}
+}
+
+// NewDocumentStatusEvent builds the envelope emitted when a document-level
+// status update is sent to MDS. A single messageID transitions through
+// several statuses over its lifetime, so ID is derived from messageID and
+// statusTransition together - see NewPluginCompletedEvent for why the bare
+// messageID isn't unique enough per the CloudEvents spec.
+func NewDocumentStatusEvent(messageID string, now time.Time, instanceID, documentName, statusTransition string, data []byte) Event {
+	return Event{
+		ID:              messageID + "/" + statusTransition,
+		Source:          Source,
+		SpecVersion:     SpecVersion,
+		Type:            DocumentStatusType,
+		Time:            now,
+		DataContentType: DataContentType,
+		Data:            data,
+		Extensions: map[string]string{
+			"ssmmessageid":    messageID,
+			"ssminstanceid":   instanceID,
+			"ssmdocumentname": documentName,
+		},
+	}
+}
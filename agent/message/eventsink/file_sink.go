@@ -0,0 +1,98 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package eventsink
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// fileEnvelope is the JSON-lines representation written by FileSink - the
+// CloudEvents structured content mode, one envelope per line.
+type fileEnvelope struct {
+	ID              string            `json:"id"`
+	Source          string            `json:"source"`
+	SpecVersion     string            `json:"specversion"`
+	Type            string            `json:"type"`
+	Time            time.Time         `json:"time"`
+	DataContentType string            `json:"datacontenttype"`
+	Data            json.RawMessage   `json:"data"`
+	Extensions      map[string]string `json:"-"`
+}
+
+// MarshalJSON flattens CloudEvents extension attributes to the top level of
+// the envelope, per the CloudEvents JSON event format.
+func (e fileEnvelope) MarshalJSON() ([]byte, error) {
+	flat := map[string]interface{}{
+		"id":              e.ID,
+		"source":          e.Source,
+		"specversion":     e.SpecVersion,
+		"type":            e.Type,
+		"time":            e.Time,
+		"datacontenttype": e.DataContentType,
+		"data":            e.Data,
+	}
+	for name, value := range e.Extensions {
+		flat[name] = value
+	}
+	return json.Marshal(flat)
+}
+
+// FileSink appends every event to a JSON-lines file on disk, for on-instance
+// debugging when there is no remote collector configured.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink opens (creating if necessary) path for append and returns a
+// FileSink writing to it.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{path: path, file: file}, nil
+}
+
+// Send implements Sink.
+func (f *FileSink) Send(event Event) error {
+	line, err := json.Marshal(fileEnvelope{
+		ID:              event.ID,
+		Source:          event.Source,
+		SpecVersion:     event.SpecVersion,
+		Type:            event.Type,
+		Time:            event.Time,
+		DataContentType: event.DataContentType,
+		Data:            event.Data,
+		Extensions:      event.Extensions,
+	})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, err = f.file.Write(line)
+	return err
+}
+
+// Close closes the underlying file.
+func (f *FileSink) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,65 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package eventsink
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPSink delivers events using the CloudEvents HTTP binary content mode:
+// the required attributes become `ce-*` headers and Data is posted as-is.
+type HTTPSink struct {
+	Endpoint string
+	Client   *http.Client
+}
+
+// NewHTTPSink builds an HTTPSink posting to endpoint with the given timeout.
+func NewHTTPSink(endpoint string, timeout time.Duration) *HTTPSink {
+	return &HTTPSink{
+		Endpoint: endpoint,
+		Client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// Send implements Sink.
+func (h *HTTPSink) Send(event Event) error {
+	req, err := http.NewRequest(http.MethodPost, h.Endpoint, bytes.NewReader(event.Data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", event.DataContentType)
+	req.Header.Set("ce-id", event.ID)
+	req.Header.Set("ce-source", event.Source)
+	req.Header.Set("ce-specversion", event.SpecVersion)
+	req.Header.Set("ce-type", event.Type)
+	req.Header.Set("ce-time", event.Time.UTC().Format(time.RFC3339Nano))
+	for name, value := range event.Extensions {
+		req.Header.Set("ce-"+name, value)
+	}
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("event sink endpoint %v returned status %v", h.Endpoint, resp.StatusCode)
+	}
+	return nil
+}
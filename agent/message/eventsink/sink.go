@@ -0,0 +1,28 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package eventsink
+
+// Sink delivers a single CloudEvents envelope. Implementations are called
+// from the Dispatcher's single delivery goroutine, so they don't need to be
+// safe for concurrent use, but a slow or blocking Send only delays later
+// events - it never delays the MDS reply that produced this one.
+type Sink interface {
+	Send(event Event) error
+}
+
+// NopSink discards every event. Used when no sink is configured.
+type NopSink struct{}
+
+// Send implements Sink.
+func (NopSink) Send(Event) error { return nil }
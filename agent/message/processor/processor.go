@@ -15,6 +15,7 @@
 package processor
 
 import (
+	stdcontext "context"
 	"encoding/json"
 	"path"
 	"time"
@@ -27,6 +28,7 @@ import (
 	"github.com/aws/amazon-ssm-agent/agent/jsonutil"
 	"github.com/aws/amazon-ssm-agent/agent/log"
 	messageContracts "github.com/aws/amazon-ssm-agent/agent/message/contracts"
+	"github.com/aws/amazon-ssm-agent/agent/message/eventsink"
 	"github.com/aws/amazon-ssm-agent/agent/message/parser"
 	"github.com/aws/amazon-ssm-agent/agent/message/service"
 	commandStateHelper "github.com/aws/amazon-ssm-agent/agent/message/statemanager"
@@ -34,6 +36,7 @@ import (
 	"github.com/aws/amazon-ssm-agent/agent/sdkutil"
 	"github.com/aws/amazon-ssm-agent/agent/task"
 	"github.com/aws/amazon-ssm-agent/agent/times"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go/service/ssmmds"
 	"github.com/carlescere/scheduler"
 )
@@ -53,7 +56,8 @@ const (
 	// name is the core plugin name
 	name = "MessageProcessor"
 
-	// pollMessageFrequencyMinutes is the frequency at which to resume poll for messages if the current thread dies due to stop policy
+	// pollMessageFrequencyMinutes is the fallback frequency at which to resume poll for messages if the current
+	// thread dies due to stop policy, used when the configured DefaultsMode has no tuned value of its own.
 	// note: the connection timeout for MDSPoll should be less than this.
 	pollMessageFrequencyMinutes = 15
 
@@ -87,6 +91,18 @@ type Processor struct {
 	orchestrationRootDir string
 	messagePollJob       *scheduler.Job
 	processorStopPolicy  *sdkutil.StopPolicy
+	eventDispatcher      *eventsink.Dispatcher
+
+	// pollFrequencyMinutes is how often messagePollJob should be rescheduled
+	// if the processor's current polling thread dies due to stop policy,
+	// derived from the configured DefaultsMode - see pollFrequencyMinutes().
+	//
+	// NOTE: the long-poll loop that schedules messagePollJob (and would read
+	// this field to pick its interval) is not part of this package in this
+	// tree/series - this field makes the mode-derived value available to
+	// that loop, but nothing here calls scheduler.Every(p.pollFrequencyMinutes)
+	// yet. Wiring it up is out of scope until that loop exists in this package.
+	pollFrequencyMinutes int
 }
 
 // PluginRunner is a function that can run a set of plugins and return their outputs.
@@ -108,7 +124,11 @@ func NewProcessor(context context.T) *Processor {
 		return nil
 	}
 
-	mdsService := newMdsService(config)
+	mdsService, err := newMdsService(config)
+	if err != nil {
+		log.Errorf("failed to create mds service, %v", err)
+		return nil
+	}
 
 	agentInfo := contracts.AgentInfo{
 		Lang:      config.Os.Lang,
@@ -145,12 +165,18 @@ func NewProcessor(context context.T) *Processor {
 	// create a stop policy where we will stop after 10 consecutive errors and if time period expires.
 	processorStopPolicy := newStopPolicy()
 
+	// eventDispatcher mirrors every reply we send to MDS as a CloudEvent, on a
+	// best-effort basis - a slow or unreachable sink only drops events, it
+	// never delays the MDS reply itself.
+	eventDispatcher := newEventDispatcher(log, config)
+
 	// SendResponse is used to send response on plugin completion.
 	// If pluginID is empty it will send responses of all plugins.
 	// If pluginID is specified, response will be sent of that particular plugin.
 	sendResponse := func(messageID string, pluginID string, results map[string]*contracts.PluginResult) {
 		payloadDoc := replyBuilder(pluginID, results)
 		processSendReply(log, messageID, mdsService, payloadDoc, processorStopPolicy)
+		dispatchPluginCompletedEvent(eventDispatcher, clock, instanceID, messageID, pluginID, payloadDoc)
 	}
 
 	// SendDocLevelResponse is used to send document level update
@@ -158,6 +184,7 @@ func NewProcessor(context context.T) *Processor {
 	sendDocLevelResponse := func(messageID string, resultStatus contracts.ResultStatus, documentTraceOutput string) {
 		payloadDoc := statusReplyBuilder(agentInfo, resultStatus, documentTraceOutput)
 		processSendReply(log, messageID, mdsService, payloadDoc, processorStopPolicy)
+		dispatchDocumentStatusEvent(eventDispatcher, clock, instanceID, messageID, string(resultStatus), payloadDoc)
 	}
 
 	// PersistData is used to persist the data into a bookkeeping folder
@@ -179,6 +206,8 @@ func NewProcessor(context context.T) *Processor {
 		orchestrationRootDir: orchestrationRootDir,
 		persistData:          persistData,
 		processorStopPolicy:  processorStopPolicy,
+		eventDispatcher:      eventDispatcher,
+		pollFrequencyMinutes: pollFrequencyMinutes(service.DefaultsMode(config.Mds.DefaultsMode)),
 	}
 }
 
@@ -195,17 +224,101 @@ func processSendReply(log log.T, messageID string, mdsService service.Service, p
 	}
 }
 
-var newMdsService = func(config appconfig.SsmagentConfig) service.Service {
+// newEventDispatcher builds the CloudEvents dispatcher used to mirror MDS
+// replies to the sink configured in config.Events. An unconfigured or
+// unrecognized sink type falls back to eventsink.NopSink, which discards
+// every event - CloudEvents emission is strictly additive and never a
+// precondition for sending MDS replies.
+func newEventDispatcher(log log.T, config appconfig.SsmagentConfig) *eventsink.Dispatcher {
+	var sink eventsink.Sink
+
+	switch config.Events.SinkType {
+	case "http":
+		sink = eventsink.NewHTTPSink(config.Events.HTTPEndpoint, time.Duration(config.Events.HTTPTimeoutMillis)*time.Millisecond)
+	case "file":
+		fileSink, err := eventsink.NewFileSink(config.Events.FilePath)
+		if err != nil {
+			log.Errorf("failed to open event sink file %v, falling back to no-op sink: %v", config.Events.FilePath, err)
+			sink = eventsink.NopSink{}
+		} else {
+			sink = fileSink
+		}
+	default:
+		sink = eventsink.NopSink{}
+	}
+
+	return eventsink.NewDispatcher(log, sink, config.Events.BufferSize)
+}
+
+// dispatchPluginCompletedEvent mirrors a single plugin's reply payload as a
+// CloudEvent. A marshal failure skips the event without logging here
+// (processSendReply has already logged the failure for the same payload) -
+// it never affects the MDS reply that already succeeded or failed on its own.
+func dispatchPluginCompletedEvent(dispatcher *eventsink.Dispatcher, clock times.Clock, instanceID, messageID, pluginID string, payloadDoc messageContracts.SendReplyPayload) {
+	data, err := json.Marshal(payloadDoc)
+	if err != nil {
+		return
+	}
+	dispatcher.Dispatch(eventsink.NewPluginCompletedEvent(messageID, clock.Now(), instanceID, "", pluginID, data))
+}
+
+// dispatchDocumentStatusEvent mirrors a document-level status update as a
+// CloudEvent. statusTransition (e.g. "InProgress", "Success") distinguishes
+// the several events a single messageID produces over its lifetime - see
+// eventsink.NewDocumentStatusEvent.
+func dispatchDocumentStatusEvent(dispatcher *eventsink.Dispatcher, clock times.Clock, instanceID, messageID, statusTransition string, payloadDoc messageContracts.SendReplyPayload) {
+	data, err := json.Marshal(payloadDoc)
+	if err != nil {
+		return
+	}
+	dispatcher.Dispatch(eventsink.NewDocumentStatusEvent(messageID, clock.Now(), instanceID, "", statusTransition, data))
+}
+
+var newMdsService = func(config appconfig.SsmagentConfig) (service.Service, error) {
 	connectionTimeout := time.Duration(config.Mds.StopTimeoutMillis) * time.Millisecond
 
+	credentialsProvider := newCredentialsProvider(config)
+
 	return service.NewService(
 		config.Agent.Region,
 		config.Mds.Endpoint,
-		nil,
+		credentialsProvider,
 		connectionTimeout,
+		service.DefaultsMode(config.Mds.DefaultsMode),
 	)
 }
 
+// newCredentialsProvider wraps the default aws-sdk-go-v2 credential chain in
+// a cache with a jittered early-expiry window, so agents across a fleet that
+// start polling at the same moment don't all refresh IMDS credentials at
+// once. Falls back to nil (the SDK's own default chain, uncached by this
+// package) if the default config can't be loaded.
+func newCredentialsProvider(config appconfig.SsmagentConfig) service.CredentialsProvider {
+	awsCfg, err := awsconfig.LoadDefaultConfig(stdcontext.Background(), awsconfig.WithRegion(config.Agent.Region))
+	if err != nil {
+		return nil
+	}
+
+	return service.NewCachingCredentialsProvider(awsCfg.Credentials, service.CredentialsCacheOptions{
+		ExpiryWindow:           time.Duration(config.Mds.CredentialsCacheExpiryWindowMinutes) * time.Minute,
+		ExpiryWindowJitterFrac: config.Mds.CredentialsCacheExpiryWindowJitterFrac,
+	})
+}
+
+// pollFrequencyMinutes derives the message poll resume frequency from the
+// configured DefaultsMode, falling back to pollMessageFrequencyMinutes for
+// modes with no tuned value of their own.
+func pollFrequencyMinutes(mode service.DefaultsMode) int {
+	switch mode {
+	case service.DefaultsModeInRegion:
+		return 10
+	case service.DefaultsModeCrossRegion, service.DefaultsModeMobile:
+		return 20
+	default:
+		return pollMessageFrequencyMinutes
+	}
+}
+
 var newStopPolicy = func() *sdkutil.StopPolicy {
 	return sdkutil.NewStopPolicy(name, stopPolicyErrorThreshold)
 }
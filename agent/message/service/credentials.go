@@ -0,0 +1,52 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// CredentialsProvider retrieves credentials for signing MDS requests. It is
+// satisfied by *aws.CredentialsCache, so production callers get caching and
+// jittered early-expiry for free, while tests can supply a stub.
+type CredentialsProvider interface {
+	Retrieve(ctx context.Context) (aws.Credentials, error)
+}
+
+// CredentialsCacheOptions tunes the early-expiry behavior of the credentials
+// cache wrapping the underlying provider. Jittering the expiry window avoids
+// many agents on a fleet refreshing IMDS credentials at the exact same
+// instant.
+type CredentialsCacheOptions struct {
+	// ExpiryWindow refreshes credentials this long before their actual expiry.
+	ExpiryWindow time.Duration
+
+	// ExpiryWindowJitterFrac randomly shortens ExpiryWindow by up to this
+	// fraction, e.g. 0.2 refreshes between 80% and 100% of ExpiryWindow
+	// before actual expiry.
+	ExpiryWindowJitterFrac float64
+}
+
+// NewCachingCredentialsProvider wraps provider in an aws.CredentialsCache
+// configured with opts, so repeated Retrieve calls within the expiry window
+// reuse the same credentials instead of hitting IMDS/STS again.
+func NewCachingCredentialsProvider(provider aws.CredentialsProvider, opts CredentialsCacheOptions) CredentialsProvider {
+	return aws.NewCredentialsCache(provider, func(o *aws.CredentialsCacheOptions) {
+		o.ExpiryWindow = opts.ExpiryWindow
+		o.ExpiryWindowJitterFrac = opts.ExpiryWindowJitterFrac
+	})
+}
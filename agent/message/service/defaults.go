@@ -0,0 +1,75 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package service implements the MDS client used by the message processor.
+package service
+
+import "time"
+
+// DefaultsMode selects a bundle of connect/read timeouts and retry counts
+// tuned for a deployment shape, mirroring aws-sdk-go-v2's defaults modes.
+type DefaultsMode string
+
+const (
+	// DefaultsModeStandard is the general purpose default, suitable when the
+	// deployment shape is unknown.
+	DefaultsModeStandard DefaultsMode = "standard"
+
+	// DefaultsModeInRegion assumes the agent and the MDS endpoint it talks to
+	// are in the same AWS region, so it favors low timeouts and fewer retries.
+	DefaultsModeInRegion DefaultsMode = "in-region"
+
+	// DefaultsModeCrossRegion assumes calls cross AWS regions and allows for
+	// the added latency with longer timeouts and more retries.
+	DefaultsModeCrossRegion DefaultsMode = "cross-region"
+
+	// DefaultsModeMobile is tuned for high-latency, higher-packet-loss
+	// networks such as cellular or satellite-connected instances.
+	DefaultsModeMobile DefaultsMode = "mobile"
+
+	// DefaultsModeAuto is accepted, but it is not resolved to in-region or
+	// cross-region: resolveTuning gives it the same fallback as an
+	// unrecognized mode.
+	DefaultsModeAuto DefaultsMode = "auto"
+)
+
+// clientTuning is the set of knobs a DefaultsMode resolves to.
+type clientTuning struct {
+	connectTimeout time.Duration
+	maxRetries     int
+}
+
+var tuningByMode = map[DefaultsMode]clientTuning{
+	DefaultsModeStandard:    {connectTimeout: 10 * time.Second, maxRetries: 3},
+	DefaultsModeInRegion:    {connectTimeout: 5 * time.Second, maxRetries: 2},
+	DefaultsModeCrossRegion: {connectTimeout: 15 * time.Second, maxRetries: 5},
+	DefaultsModeMobile:      {connectTimeout: 30 * time.Second, maxRetries: 8},
+}
+
+// resolveTuning returns the timeout/retry bundle for mode, falling back to
+// connectionTimeout (computed from legacy config) when mode is unrecognized
+// or DefaultsModeAuto - auto-resolution to in-region vs cross-region requires
+// comparing the resolved IMDS region to the configured region, which callers
+// that care about should do before calling NewService. The fallback keeps the
+// standard retry count; a zero connectionTimeout yields the standard bundle.
+func resolveTuning(mode DefaultsMode, connectionTimeout time.Duration) clientTuning {
+	if tuning, ok := tuningByMode[mode]; ok {
+		return tuning
+	}
+
+	if connectionTimeout > 0 {
+		return clientTuning{connectTimeout: connectionTimeout, maxRetries: tuningByMode[DefaultsModeStandard].maxRetries}
+	}
+
+	return tuningByMode[DefaultsModeStandard]
+}
@@ -0,0 +1,94 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/amazon-ssm-agent/agent/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssmmds"
+)
+
+// Service is the interface the message processor uses to talk to MDS. The
+// Go signatures here are unchanged from the aws-sdk-go v1 implementation on
+// purpose - only what happens underneath (credential caching, defaults mode
+// tuning, v2 middleware) has changed.
+type Service interface {
+	// SendReply posts payload as the reply to the MDS message identified by messageID.
+	SendReply(log log.T, messageID string, payload string) error
+}
+
+// mdsClient is the subset of the generated ssmmds v2 client this package
+// depends on, narrowed so tests can stub it out.
+type mdsClient interface {
+	SendReply(ctx context.Context, params *ssmmds.SendReplyInput, optFns ...func(*ssmmds.Options)) (*ssmmds.SendReplyOutput, error)
+}
+
+type mdsService struct {
+	client  mdsClient
+	timeout time.Duration
+}
+
+// NewService builds the MDS client used by the processor. credentialsProvider
+// is typically the result of NewCachingCredentialsProvider; passing nil falls
+// back to the SDK's default credential chain. connectionTimeout is honored as
+// a floor - defaultsMode may widen it (e.g. cross-region, mobile) but never
+// narrows it below what the caller asked for.
+//
+// LoadDefaultConfig can fail on environmental issues (e.g. an unreachable
+// IMDS or a malformed shared config file), not just programmer error, so
+// callers get an error back rather than a panic - mirroring how
+// newCredentialsProvider already treats the same failure as recoverable.
+func NewService(region string, endpoint string, credentialsProvider CredentialsProvider, connectionTimeout time.Duration, defaultsMode DefaultsMode) (Service, error) {
+	tuning := resolveTuning(defaultsMode, connectionTimeout)
+	if connectionTimeout > tuning.connectTimeout {
+		tuning.connectTimeout = connectionTimeout
+	}
+
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(region),
+		awsconfig.WithRetryMaxAttempts(tuning.maxRetries),
+	}
+	if credentialsProvider != nil {
+		opts = append(opts, awsconfig.WithCredentialsProvider(credentialsProvider))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to load aws config: %v", err)
+	}
+
+	client := ssmmds.NewFromConfig(cfg, func(o *ssmmds.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+
+	return &mdsService{client: client, timeout: tuning.connectTimeout}, nil
+}
+
+func (s *mdsService) SendReply(log log.T, messageID string, payload string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	_, err := s.client.SendReply(ctx, &ssmmds.SendReplyInput{
+		MessageId: aws.String(messageID),
+		Payload:   aws.String(payload),
+	})
+	return err
+}
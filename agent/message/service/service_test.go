@@ -0,0 +1,92 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ssm-agent/agent/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssmmds"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingCredentialsProvider records how many times Retrieve actually ran
+// the (expensive, stubbed-as-fake) credential fetch, so tests can assert the
+// cache is reusing results instead of hitting it on every call.
+type countingCredentialsProvider struct {
+	fetches int
+}
+
+func (p *countingCredentialsProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	p.fetches++
+	return aws.Credentials{
+		AccessKeyID:     "AKIAFAKE",
+		SecretAccessKey: "fakesecret",
+		Expires:         time.Now().Add(time.Hour),
+		CanExpire:       true,
+	}, nil
+}
+
+type stubMdsClient struct {
+	calls int
+}
+
+func (s *stubMdsClient) SendReply(ctx context.Context, params *ssmmds.SendReplyInput, optFns ...func(*ssmmds.Options)) (*ssmmds.SendReplyOutput, error) {
+	s.calls++
+	return &ssmmds.SendReplyOutput{}, nil
+}
+
+func TestCachingCredentialsProviderReusesCredentialsWithinExpiryWindow(t *testing.T) {
+	fake := &countingCredentialsProvider{}
+	cache := NewCachingCredentialsProvider(fake, CredentialsCacheOptions{
+		ExpiryWindow:           time.Minute,
+		ExpiryWindowJitterFrac: 0.1,
+	})
+
+	for i := 0; i < 5; i++ {
+		_, err := cache.Retrieve(context.Background())
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, fake.fetches, "expected credentials to be fetched once and reused from the cache")
+}
+
+func TestNewServiceReturnsErrorInsteadOfPanickingOnLoadFailure(t *testing.T) {
+	// Pointing AWS_CONFIG_FILE at a directory forces awsconfig.LoadDefaultConfig
+	// to fail reading it, simulating an environmental failure (unlike a
+	// malformed static option, which would be a programmer error).
+	originalConfigFile := os.Getenv("AWS_CONFIG_FILE")
+	defer os.Setenv("AWS_CONFIG_FILE", originalConfigFile)
+	os.Setenv("AWS_CONFIG_FILE", t.TempDir())
+
+	assert.NotPanics(t, func() {
+		svc, err := NewService("us-east-1", "", nil, time.Second, DefaultsModeStandard)
+		assert.Error(t, err)
+		assert.Nil(t, svc)
+	})
+}
+
+func TestMdsServiceSendReply(t *testing.T) {
+	client := &stubMdsClient{}
+	svc := &mdsService{client: client, timeout: time.Second}
+
+	err := svc.SendReply(log.NewMockLog(), "messageId", "{}")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, client.calls)
+}
@@ -0,0 +1,59 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build windows
+
+package svcutil
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// SetRegistryValue writes a single string registry value, translating the
+// `HKLM:\...` path form used by the nano manifest and native action list
+// into the registry package's hive + subkey form. It is a var, rather than
+// a func, so tests can stub it out without touching the real registry.
+var SetRegistryValue = func(path, name, value string) error {
+	hive, subKeyPath, err := splitHivePath(path)
+	if err != nil {
+		return err
+	}
+
+	regKey, _, err := registry.CreateKey(hive, subKeyPath, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer regKey.Close()
+
+	return regKey.SetStringValue(name, value)
+}
+
+func splitHivePath(path string) (registry.Key, string, error) {
+	parts := strings.SplitN(path, `\`, 2)
+	subKeyPath := ""
+	if len(parts) == 2 {
+		subKeyPath = parts[1]
+	}
+
+	switch strings.ToUpper(strings.TrimSuffix(parts[0], ":")) {
+	case "HKLM", "HKEY_LOCAL_MACHINE":
+		return registry.LOCAL_MACHINE, subKeyPath, nil
+	case "HKCU", "HKEY_CURRENT_USER":
+		return registry.CURRENT_USER, subKeyPath, nil
+	default:
+		return 0, "", fmt.Errorf("unsupported registry hive in path %v", path)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build windows
+
+// Package svcutil holds the sc.exe and registry primitives shared by the
+// nano and nativeinstaller packages, both of which drive a PowerShell-free
+// install/uninstall path and would otherwise duplicate this logic.
+package svcutil
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/aws/amazon-ssm-agent/agent/log"
+)
+
+// ExecCommand is overridden in tests.
+var ExecCommand = exec.Command
+
+// ServicePollInterval is how often WaitForServiceStopped polls `sc query`.
+const ServicePollInterval = 500 * time.Millisecond
+
+// RunSc shells out to sc.exe, which is present even on Nano Server images
+// that have PowerShell and WMF removed.
+func RunSc(log log.T, args ...string) error {
+	output, err := ExecCommand("sc.exe", args...).CombinedOutput()
+	if err != nil {
+		log.Errorf("sc.exe %v failed: %v - %v", args, err, string(output))
+		return err
+	}
+	log.Debugf("sc.exe %v: %v", args, string(output))
+	return nil
+}
+
+// WaitForServiceStopped polls `sc query serviceName` until it reports STOPPED
+// or timeoutSeconds elapses. `sc stop` only requests a stop and returns
+// immediately, so callers that are about to overwrite the service binary
+// must wait for the process to actually exit first, or the copy will fail
+// with a sharing violation.
+func WaitForServiceStopped(log log.T, serviceName string, timeoutSeconds int) error {
+	deadline := time.Now().Add(time.Duration(timeoutSeconds) * time.Second)
+
+	for {
+		output, err := ExecCommand("sc.exe", "query", serviceName).Output()
+		if err == nil && bytes.Contains(output, []byte("STOPPED")) {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %vs waiting for service %v to stop", timeoutSeconds, serviceName)
+		}
+
+		log.Debugf("waiting for service %v to stop", serviceName)
+		time.Sleep(ServicePollInterval)
+	}
+}
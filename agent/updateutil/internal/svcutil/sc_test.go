@@ -0,0 +1,60 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build windows
+
+package svcutil
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/aws/amazon-ssm-agent/agent/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeExecCommand runs the current test binary as a stand-in process so
+// tests never shell out to the real sc.exe. queryOutput controls what a
+// `sc.exe query` invocation appears to return.
+var queryOutput = "STOPPED"
+
+func fakeExecCommand(command string, args ...string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], "-test.run=TestHelperProcess")
+	cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1", "GO_HELPER_QUERY_OUTPUT="+queryOutput)
+	return cmd
+}
+
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	os.Stdout.WriteString(os.Getenv("GO_HELPER_QUERY_OUTPUT"))
+	os.Exit(0)
+}
+
+func TestWaitForServiceStoppedReturnsImmediatelyWhenAlreadyStopped(t *testing.T) {
+	ExecCommand = fakeExecCommand
+	defer func() { ExecCommand = exec.Command }()
+	queryOutput = "STATE : 1 STOPPED"
+
+	assert.NoError(t, WaitForServiceStopped(log.NewMockLog(), "AmazonSSMAgent", 60))
+}
+
+func TestWaitForServiceStoppedTimesOutWhenStillRunning(t *testing.T) {
+	ExecCommand = fakeExecCommand
+	defer func() { ExecCommand = exec.Command }()
+	queryOutput = "STATE : 4 RUNNING"
+
+	assert.Error(t, WaitForServiceStopped(log.NewMockLog(), "AmazonSSMAgent", 0))
+}
@@ -0,0 +1,141 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build windows
+
+// Package nano implements a PowerShell-independent install/uninstall path for
+// Nano Server targets, driven by a small JSON manifest shipped inside the
+// update package.
+package nano
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/amazon-ssm-agent/agent/fileutil"
+	"github.com/aws/amazon-ssm-agent/agent/log"
+	"github.com/aws/amazon-ssm-agent/agent/updateutil/internal/svcutil"
+)
+
+// ManifestFileName is the name of the nano install manifest expected at the
+// root of an update package when the package supports Nano Server.
+const ManifestFileName = "nano-manifest.json"
+
+// serviceRestartTimeoutSeconds bounds how long Install waits for the service
+// to reach STOPPED before replacing its binary.
+const serviceRestartTimeoutSeconds = 60
+
+// RegistryKey describes a single registry value the manifest wants written
+// as part of install.
+type RegistryKey struct {
+	// Path is the registry key path, e.g. `HKLM:\SOFTWARE\Amazon\SSM`.
+	Path string `json:"path"`
+	// Name is the value name.
+	Name string `json:"name"`
+	// Value is the string form of the value to write.
+	Value string `json:"value"`
+}
+
+// Manifest describes everything the native installer needs to replace the
+// agent service binary on a Nano Server host without PowerShell.
+type Manifest struct {
+	// ServiceName is the Windows service to stop/replace/start, e.g. AmazonSSMAgent.
+	ServiceName string `json:"serviceName"`
+	// BinaryPath is the path, relative to the package root, of the new service binary.
+	BinaryPath string `json:"binaryPath"`
+	// RegistryKeys are additional registry values to set after the binary is in place.
+	RegistryKeys []RegistryKey `json:"registryKeys,omitempty"`
+}
+
+// HasManifest returns true when packageDir contains a nano install manifest.
+func HasManifest(packageDir string) bool {
+	_, err := os.Stat(filepath.Join(packageDir, ManifestFileName))
+	return err == nil
+}
+
+// LoadManifest reads and validates the nano manifest from packageDir.
+func LoadManifest(packageDir string) (*Manifest, error) {
+	manifestPath := filepath.Join(packageDir, ManifestFileName)
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read nano manifest %v: %v", manifestPath, err)
+	}
+
+	manifest := &Manifest{}
+	if err = json.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse nano manifest %v: %v", manifestPath, err)
+	}
+
+	if manifest.ServiceName == "" {
+		return nil, fmt.Errorf("nano manifest %v is missing serviceName", manifestPath)
+	}
+	if manifest.BinaryPath == "" {
+		return nil, fmt.Errorf("nano manifest %v is missing binaryPath", manifestPath)
+	}
+
+	return manifest, nil
+}
+
+// Install stops the service named in the manifest, replaces its binary with
+// the one shipped in packageDir, applies any registry keys, and restarts the
+// service - all via sc.exe and native file operations, with no dependency on
+// PowerShell or WMF.
+func Install(log log.T, packageDir, targetBinaryPath string) error {
+	manifest, err := LoadManifest(packageDir)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("nano install: stopping service %v", manifest.ServiceName)
+	if err = svcutil.RunSc(log, "stop", manifest.ServiceName); err != nil {
+		log.Infof("nano install: service %v was not running: %v", manifest.ServiceName, err)
+	}
+
+	// `sc stop` only requests a stop and returns before the process actually
+	// exits; copying over its binary before it has fully stopped fails with a
+	// sharing violation, so wait for the service to reach STOPPED first.
+	if err = svcutil.WaitForServiceStopped(log, manifest.ServiceName, serviceRestartTimeoutSeconds); err != nil {
+		return fmt.Errorf("failed waiting for service %v to stop before replacing its binary: %v", manifest.ServiceName, err)
+	}
+
+	sourcePath := filepath.Join(packageDir, manifest.BinaryPath)
+	log.Infof("nano install: copying %v to %v", sourcePath, targetBinaryPath)
+	if err = fileutil.CopyFile(targetBinaryPath, sourcePath); err != nil {
+		return fmt.Errorf("failed to replace service binary: %v", err)
+	}
+
+	for _, key := range manifest.RegistryKeys {
+		log.Infof("nano install: setting registry value %v\\%v", key.Path, key.Name)
+		if err = svcutil.SetRegistryValue(key.Path, key.Name, key.Value); err != nil {
+			return fmt.Errorf("failed to set registry value %v\\%v: %v", key.Path, key.Name, err)
+		}
+	}
+
+	log.Infof("nano install: starting service %v", manifest.ServiceName)
+	return svcutil.RunSc(log, "start", manifest.ServiceName)
+}
+
+// Uninstall stops the service named in the manifest. It intentionally leaves
+// the binary and registry state behind for the caller (the updater) to clean
+// up, mirroring the separation of concerns in install.ps1/uninstall.ps1.
+func Uninstall(log log.T, packageDir string) error {
+	manifest, err := LoadManifest(packageDir)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("nano uninstall: stopping service %v", manifest.ServiceName)
+	return svcutil.RunSc(log, "stop", manifest.ServiceName)
+}
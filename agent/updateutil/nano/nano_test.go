@@ -0,0 +1,47 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build windows
+
+package nano
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadManifestValidatesRequiredFields(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, ManifestFileName)
+
+	assert.NoError(t, os.WriteFile(manifestPath, []byte(`{"serviceName":""}`), 0644))
+	_, err := LoadManifest(dir)
+	assert.Error(t, err)
+
+	assert.NoError(t, os.WriteFile(manifestPath, []byte(`{"serviceName":"AmazonSSMAgent","binaryPath":"agent.exe"}`), 0644))
+	manifest, err := LoadManifest(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "AmazonSSMAgent", manifest.ServiceName)
+	assert.Equal(t, "agent.exe", manifest.BinaryPath)
+}
+
+func TestHasManifest(t *testing.T) {
+	dir := t.TempDir()
+	assert.False(t, HasManifest(dir))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ManifestFileName), []byte(`{}`), 0644))
+	assert.True(t, HasManifest(dir))
+}
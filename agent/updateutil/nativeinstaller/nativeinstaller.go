@@ -0,0 +1,123 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build windows
+
+// Package nativeinstaller runs a JSON action list shipped inside an update
+// package without invoking PowerShell, for hosts where PowerShell is absent
+// or running in Constrained Language Mode.
+package nativeinstaller
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/amazon-ssm-agent/agent/fileutil"
+	"github.com/aws/amazon-ssm-agent/agent/log"
+	"github.com/aws/amazon-ssm-agent/agent/updateutil/internal/svcutil"
+)
+
+// ActionsFileName is the name of the native action list expected at the root
+// of an update package when the package's installer manifest declares type "native".
+const ActionsFileName = "native-actions.json"
+
+// Action is a single native installer primitive.
+type Action struct {
+	// Type is one of: copy, delete, mkdir, service-stop, service-start, sc-config, registry-set.
+	Type string `json:"type"`
+
+	// Source and Destination are used by "copy"; Source is relative to the package root.
+	Source      string `json:"source,omitempty"`
+	Destination string `json:"destination,omitempty"`
+
+	// Path is used by "delete" and "mkdir".
+	Path string `json:"path,omitempty"`
+
+	// ServiceName is used by "service-stop", "service-start" and "sc-config".
+	ServiceName string `json:"serviceName,omitempty"`
+
+	// ConfigOption and ConfigValue are used by "sc-config", e.g. option "start" value "demand".
+	ConfigOption string `json:"configOption,omitempty"`
+	ConfigValue  string `json:"configValue,omitempty"`
+
+	// RegistryPath, RegistryName and RegistryValue are used by "registry-set".
+	// RegistryPath takes the `HKLM:\...` form.
+	RegistryPath  string `json:"registryPath,omitempty"`
+	RegistryName  string `json:"registryName,omitempty"`
+	RegistryValue string `json:"registryValue,omitempty"`
+}
+
+// ActionList is the top level document in ActionsFileName. Actions runs on
+// install; UninstallActions runs on uninstall (e.g. to stop the service and
+// leave the older binary in place for the updater to restore).
+type ActionList struct {
+	Actions          []Action `json:"actions"`
+	UninstallActions []Action `json:"uninstallActions,omitempty"`
+}
+
+// HasActions returns true when packageDir contains a native action list.
+func HasActions(packageDir string) bool {
+	_, err := os.Stat(filepath.Join(packageDir, ActionsFileName))
+	return err == nil
+}
+
+// LoadActionList reads and parses the native action list from packageDir.
+func LoadActionList(packageDir string) (*ActionList, error) {
+	actionsPath := filepath.Join(packageDir, ActionsFileName)
+	content, err := os.ReadFile(actionsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read native action list %v: %v", actionsPath, err)
+	}
+
+	actionList := &ActionList{}
+	if err = json.Unmarshal(content, actionList); err != nil {
+		return nil, fmt.Errorf("failed to parse native action list %v: %v", actionsPath, err)
+	}
+
+	return actionList, nil
+}
+
+// Run executes every action in order against packageDir, stopping at the
+// first failure.
+func Run(log log.T, packageDir string, actions []Action) error {
+	for _, action := range actions {
+		log.Infof("native installer: running action %v", action.Type)
+		if err := runAction(log, packageDir, action); err != nil {
+			return fmt.Errorf("native installer action %v failed: %v", action.Type, err)
+		}
+	}
+	return nil
+}
+
+func runAction(log log.T, packageDir string, action Action) error {
+	switch action.Type {
+	case "copy":
+		return fileutil.CopyFile(action.Destination, filepath.Join(packageDir, action.Source))
+	case "delete":
+		return os.RemoveAll(action.Path)
+	case "mkdir":
+		return os.MkdirAll(action.Path, 0755)
+	case "service-stop":
+		return svcutil.RunSc(log, "stop", action.ServiceName)
+	case "service-start":
+		return svcutil.RunSc(log, "start", action.ServiceName)
+	case "sc-config":
+		return svcutil.RunSc(log, "config", action.ServiceName, action.ConfigOption+"=", action.ConfigValue)
+	case "registry-set":
+		return svcutil.SetRegistryValue(action.RegistryPath, action.RegistryName, action.RegistryValue)
+	default:
+		return fmt.Errorf("unsupported native installer action type %v", action.Type)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build windows
+
+package nativeinstaller
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/amazon-ssm-agent/agent/log"
+	"github.com/aws/amazon-ssm-agent/agent/updateutil/internal/svcutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeExecCommand(command string, args ...string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], "-test.run=TestHelperProcess")
+	cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1")
+	return cmd
+}
+
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	os.Exit(0)
+}
+
+func TestRunCopyDeleteMkdir(t *testing.T) {
+	packageDir := t.TempDir()
+	destDir := t.TempDir()
+
+	sourceFile := filepath.Join(packageDir, "agent.exe")
+	assert.NoError(t, os.WriteFile(sourceFile, []byte("binary"), 0644))
+
+	destFile := filepath.Join(destDir, "agent.exe")
+	newDir := filepath.Join(destDir, "newdir")
+
+	actions := []Action{
+		{Type: "mkdir", Path: newDir},
+		{Type: "copy", Source: "agent.exe", Destination: destFile},
+		{Type: "delete", Path: sourceFile},
+	}
+
+	assert.NoError(t, Run(log.NewMockLog(), packageDir, actions))
+
+	assert.DirExists(t, newDir)
+	assert.FileExists(t, destFile)
+	assert.NoFileExists(t, sourceFile)
+}
+
+func TestRunServiceActionsDoNotInvokePowerShell(t *testing.T) {
+	svcutil.ExecCommand = fakeExecCommand
+	defer func() { svcutil.ExecCommand = exec.Command }()
+
+	actions := []Action{
+		{Type: "service-stop", ServiceName: "AmazonSSMAgent"},
+		{Type: "sc-config", ServiceName: "AmazonSSMAgent", ConfigOption: "start", ConfigValue: "demand"},
+		{Type: "service-start", ServiceName: "AmazonSSMAgent"},
+	}
+
+	assert.NoError(t, Run(log.NewMockLog(), t.TempDir(), actions))
+}
+
+func TestRunRegistrySetAction(t *testing.T) {
+	var gotPath, gotName, gotValue string
+	originalSetRegistryValue := svcutil.SetRegistryValue
+	svcutil.SetRegistryValue = func(path, name, value string) error {
+		gotPath, gotName, gotValue = path, name, value
+		return nil
+	}
+	defer func() { svcutil.SetRegistryValue = originalSetRegistryValue }()
+
+	actions := []Action{
+		{Type: "registry-set", RegistryPath: `HKLM:\SOFTWARE\Amazon\SSM`, RegistryName: "Version", RegistryValue: "3.3.0.0"},
+	}
+
+	assert.NoError(t, Run(log.NewMockLog(), t.TempDir(), actions))
+	assert.Equal(t, `HKLM:\SOFTWARE\Amazon\SSM`, gotPath)
+	assert.Equal(t, "Version", gotName)
+	assert.Equal(t, "3.3.0.0", gotValue)
+}
+
+func TestRunUnsupportedActionType(t *testing.T) {
+	err := Run(log.NewMockLog(), t.TempDir(), []Action{{Type: "format-disk"}})
+	assert.Error(t, err)
+}
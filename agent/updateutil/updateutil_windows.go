@@ -17,6 +17,8 @@
 package updateutil
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -24,6 +26,8 @@ import (
 
 	"github.com/aws/amazon-ssm-agent/agent/log"
 	"github.com/aws/amazon-ssm-agent/agent/platform"
+	"github.com/aws/amazon-ssm-agent/agent/updateutil/nano"
+	"github.com/aws/amazon-ssm-agent/agent/updateutil/nativeinstaller"
 )
 
 const (
@@ -80,6 +84,31 @@ const (
 	UnInstaller = "uninstall.ps1"
 )
 
+// InstallerType selects how an update package's install/uninstall step is executed.
+type InstallerType string
+
+const (
+	// InstallerTypePowerShell runs Installer/UnInstaller via Windows PowerShell (the legacy, default behavior).
+	InstallerTypePowerShell InstallerType = "powershell"
+
+	// InstallerTypePwsh runs Installer/UnInstaller via PowerShell 7+ (pwsh), resolved from PATH.
+	InstallerTypePwsh InstallerType = "pwsh"
+
+	// InstallerTypeNative runs a JSON action list (see the nativeinstaller package) with no PowerShell dependency at all.
+	InstallerTypeNative InstallerType = "native"
+)
+
+// InstallerManifestFileName is the manifest, shipped at the root of an
+// update package, that declares which InstallerType to use. Its absence is
+// not an error - it means the package predates this manifest and always
+// used InstallerTypePowerShell.
+const InstallerManifestFileName = "installer-manifest.json"
+
+// installerManifest is the parsed form of InstallerManifestFileName.
+type installerManifest struct {
+	Type InstallerType `json:"type"`
+}
+
 // Win32_OperatingSystems https://msdn.microsoft.com/en-us/library/aa394239%28v=vs.85%29.aspx
 const (
 	// PRODUCT_DATA_CENTER_NANO_SERVER = 143
@@ -91,6 +120,10 @@ const (
 
 var getPlatformSku = platform.PlatformSku
 
+// lookPath is overridden in tests so the InstallerTypePwsh path can be
+// exercised without a real pwsh.exe on PATH.
+var lookPath = exec.LookPath
+
 func prepareProcess(command *exec.Cmd) {
 }
 
@@ -102,7 +135,64 @@ func agentExpectedStatus() string {
 	return "RUNNING"
 }
 
-func isUpdateSupported(log log.T) (bool, error) {
+// isNanoServerSku returns true when sku identifies one of the Nano Server
+// editions, which ship without WMF/PowerShell.
+func isNanoServerSku(sku string) bool {
+	return sku == ProductDataCenterNanoServer || sku == ProductStandardNanoServer
+}
+
+// loadInstallerType reads InstallerManifestFileName from targetPackagePath.
+// A missing manifest is not an error: it means the package predates the
+// manifest and always used PowerShell.
+func loadInstallerType(targetPackagePath string) (InstallerType, error) {
+	manifestPath := filepath.Join(targetPackagePath, InstallerManifestFileName)
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return InstallerTypePowerShell, nil
+		}
+		return "", fmt.Errorf("failed to read installer manifest %v: %v", manifestPath, err)
+	}
+
+	manifest := installerManifest{}
+	if err = json.Unmarshal(content, &manifest); err != nil {
+		return "", fmt.Errorf("failed to parse installer manifest %v: %v", manifestPath, err)
+	}
+
+	if manifest.Type == "" {
+		return InstallerTypePowerShell, nil
+	}
+	return manifest.Type, nil
+}
+
+// installerTypeAvailability reports whether installerType can run on this
+// host, and a human-readable reason when it cannot.
+func installerTypeAvailability(installerType InstallerType) (bool, string) {
+	switch installerType {
+	case InstallerTypePowerShell:
+		return true, ""
+	case InstallerTypePwsh:
+		if _, err := lookPath("pwsh"); err != nil {
+			return false, "installer type pwsh requested but pwsh.exe was not found on PATH"
+		}
+		return true, ""
+	case InstallerTypeNative:
+		return true, ""
+	default:
+		return false, fmt.Sprintf("unknown installer type %q in manifest", installerType)
+	}
+}
+
+// isUpdateSupported reports whether the update can proceed on this host.
+//
+// Nano Server hosts lack PowerShell entirely, so they are only supported
+// when targetPackagePath contains a nano install manifest (nano.ManifestFileName)
+// describing a self-contained, sc.exe-only install.
+//
+// All other hosts validate the package's InstallerManifestFileName up front:
+// powershell is always available, pwsh requires pwsh.exe on PATH, and native
+// has no external dependency.
+func isUpdateSupported(log log.T, targetPackagePath string) (bool, error) {
 	var sku string
 	var err error
 
@@ -114,15 +204,80 @@ func isUpdateSupported(log log.T) (bool, error) {
 
 	log.Infof("sku: %v", sku)
 
-	// If sku represents nano server, return false
-	if sku == ProductDataCenterNanoServer || sku == ProductStandardNanoServer {
+	if isNanoServerSku(sku) {
+		if nano.HasManifest(targetPackagePath) {
+			log.Infof("nano manifest found at %v, update supported via native install path", targetPackagePath)
+			return true, nil
+		}
+		log.Infof("update not supported: sku %v is Nano Server and %v contains no %v", sku, targetPackagePath, nano.ManifestFileName)
+		return false, nil
+	}
+
+	installerType, err := loadInstallerType(targetPackagePath)
+	if err != nil {
+		log.Infof("Failed to determine installer type - %v", err)
+		return false, err
+	}
+
+	if available, reason := installerTypeAvailability(installerType); !available {
+		log.Infof("update not supported: %v", reason)
 		return false, nil
 	}
 
 	return true, nil
 }
 
-func setPlatformSpecificCommand(parts []string) []string {
-	cmd := filepath.Join(os.Getenv("SystemRoot"), "System32", "WindowsPowerShell", "v1.0", "powershell.exe") + " -ExecutionPolicy unrestricted"
-	return append(strings.Split(cmd, " "), parts...)
+// setPlatformSpecificCommand builds the argv used to run Installer/UnInstaller
+// for installerType, dispatching on the manifest-declared type. It returns an
+// error for InstallerTypeNative, which runs via runNativeInstall/
+// runNativeUninstall instead of a shelled-out command.
+func setPlatformSpecificCommand(installerType InstallerType, parts []string) ([]string, error) {
+	switch installerType {
+	case InstallerTypePowerShell, "":
+		cmd := filepath.Join(os.Getenv("SystemRoot"), "System32", "WindowsPowerShell", "v1.0", "powershell.exe") + " -ExecutionPolicy unrestricted"
+		return append(strings.Split(cmd, " "), parts...), nil
+	case InstallerTypePwsh:
+		pwshPath, err := lookPath("pwsh")
+		if err != nil {
+			return nil, fmt.Errorf("installer type pwsh requested but pwsh.exe was not found on PATH: %v", err)
+		}
+		return append([]string{pwshPath}, parts...), nil
+	case InstallerTypeNative:
+		return nil, fmt.Errorf("installer type native does not produce a shell command; use runNativeInstall/runNativeUninstall instead")
+	default:
+		return nil, fmt.Errorf("unsupported installer type %v", installerType)
+	}
+}
+
+// runNanoInstall performs a PowerShell-independent install on Nano Server
+// hosts, driven entirely by the manifest shipped in packageDir.
+func runNanoInstall(log log.T, packageDir, targetBinaryPath string) error {
+	return nano.Install(log, packageDir, targetBinaryPath)
+}
+
+// runNanoUninstall performs a PowerShell-independent uninstall on Nano
+// Server hosts, driven entirely by the manifest shipped in packageDir.
+func runNanoUninstall(log log.T, packageDir string) error {
+	return nano.Uninstall(log, packageDir)
+}
+
+// runNativeInstall runs the native action list (nativeinstaller.ActionsFileName)
+// shipped inside packageDir, for hosts whose installer manifest declares InstallerTypeNative.
+func runNativeInstall(log log.T, packageDir string) error {
+	actionList, err := nativeinstaller.LoadActionList(packageDir)
+	if err != nil {
+		return err
+	}
+	return nativeinstaller.Run(log, packageDir, actionList.Actions)
+}
+
+// runNativeUninstall runs the native uninstall action list
+// (nativeinstaller.ActionList.UninstallActions) shipped inside packageDir,
+// for hosts whose installer manifest declares InstallerTypeNative.
+func runNativeUninstall(log log.T, packageDir string) error {
+	actionList, err := nativeinstaller.LoadActionList(packageDir)
+	if err != nil {
+		return err
+	}
+	return nativeinstaller.Run(log, packageDir, actionList.UninstallActions)
 }
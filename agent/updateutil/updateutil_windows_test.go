@@ -0,0 +1,122 @@
+// Copyright 2016 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Amazon Software License (the "License"). You may not
+// use this file except in compliance with the License. A copy of the
+// License is located at
+//
+// http://aws.amazon.com/asl/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build windows
+
+package updateutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/amazon-ssm-agent/agent/log"
+	"github.com/aws/amazon-ssm-agent/agent/updateutil/nativeinstaller"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeManifest(t *testing.T, dir, installerType string) {
+	t.Helper()
+	manifestPath := filepath.Join(dir, InstallerManifestFileName)
+	assert.NoError(t, os.WriteFile(manifestPath, []byte(`{"type":"`+installerType+`"}`), 0644))
+}
+
+func TestLoadInstallerTypeDefaultsToPowerShellWhenManifestMissing(t *testing.T) {
+	installerType, err := loadInstallerType(t.TempDir())
+	assert.NoError(t, err)
+	assert.Equal(t, InstallerTypePowerShell, installerType)
+}
+
+func TestLoadInstallerTypeReadsManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, dir, "native")
+
+	installerType, err := loadInstallerType(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, InstallerTypeNative, installerType)
+}
+
+func TestSetPlatformSpecificCommandDispatchesByInstallerType(t *testing.T) {
+	parts := []string{"-File", "install.ps1"}
+
+	powershellCmd, err := setPlatformSpecificCommand(InstallerTypePowerShell, parts)
+	assert.NoError(t, err)
+	assert.Contains(t, powershellCmd[0], "powershell.exe")
+
+	_, err = setPlatformSpecificCommand(InstallerTypeNative, parts)
+	assert.Error(t, err, "native installer type has no shell command")
+
+	_, err = setPlatformSpecificCommand(InstallerType("unknown"), parts)
+	assert.Error(t, err)
+}
+
+func TestSetPlatformSpecificCommandUsesPwshFromPath(t *testing.T) {
+	originalLookPath := lookPath
+	defer func() { lookPath = originalLookPath }()
+	lookPath = func(file string) (string, error) { return `C:\Program Files\PowerShell\7\pwsh.exe`, nil }
+
+	parts := []string{"-File", "install.ps1"}
+	pwshCmd, err := setPlatformSpecificCommand(InstallerTypePwsh, parts)
+	assert.NoError(t, err)
+	assert.Equal(t, append([]string{`C:\Program Files\PowerShell\7\pwsh.exe`}, parts...), pwshCmd)
+
+	lookPath = func(file string) (string, error) { return "", fmt.Errorf("not found") }
+	_, err = setPlatformSpecificCommand(InstallerTypePwsh, parts)
+	assert.Error(t, err, "pwsh not on PATH should be an error")
+}
+
+func TestIsUpdateSupportedValidatesInstallerManifest(t *testing.T) {
+	originalGetPlatformSku := getPlatformSku
+	defer func() { getPlatformSku = originalGetPlatformSku }()
+	getPlatformSku = func(log log.T) (string, error) { return "7", nil } // Windows Server Standard
+
+	dir := t.TempDir()
+	writeManifest(t, dir, "native")
+
+	supported, err := isUpdateSupported(log.NewMockLog(), dir)
+	assert.NoError(t, err)
+	assert.True(t, supported, "native installer type should always be supported")
+}
+
+func TestIsUpdateSupportedFalseForNanoWithoutNanoManifest(t *testing.T) {
+	originalGetPlatformSku := getPlatformSku
+	defer func() { getPlatformSku = originalGetPlatformSku }()
+	getPlatformSku = func(log log.T) (string, error) { return ProductStandardNanoServer, nil }
+
+	supported, err := isUpdateSupported(log.NewMockLog(), t.TempDir())
+	assert.NoError(t, err)
+	assert.False(t, supported)
+}
+
+func TestRunNativeInstallAndUninstallRunTheirOwnActionLists(t *testing.T) {
+	dir := t.TempDir()
+	installDir := filepath.Join(dir, "installed")
+	uninstallDir := filepath.Join(dir, "uninstalled")
+
+	actionList := nativeinstaller.ActionList{
+		Actions:          []nativeinstaller.Action{{Type: "mkdir", Path: installDir}},
+		UninstallActions: []nativeinstaller.Action{{Type: "mkdir", Path: uninstallDir}},
+	}
+	content, err := json.Marshal(actionList)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, nativeinstaller.ActionsFileName), content, 0644))
+
+	assert.NoError(t, runNativeInstall(log.NewMockLog(), dir))
+	assert.DirExists(t, installDir)
+	assert.NoDirExists(t, uninstallDir)
+
+	assert.NoError(t, runNativeUninstall(log.NewMockLog(), dir))
+	assert.DirExists(t, uninstallDir)
+}